@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// uiConfig persists tree UI layout preferences across runs.
+type uiConfig struct {
+	SplitRatio    float64 `yaml:"split_ratio"`
+	PreviewHidden bool    `yaml:"preview_hidden"`
+}
+
+func defaultUIConfig() uiConfig {
+	return uiConfig{SplitRatio: 0.4}
+}
+
+func uiConfigPath() (string, error) {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "mdselector", "config.yaml"), nil
+}
+
+// loadUIConfig returns the persisted config, falling back silently to
+// defaultUIConfig if none exists yet or it can't be read.
+func loadUIConfig() uiConfig {
+	cfg := defaultUIConfig()
+
+	path, err := uiConfigPath()
+	if err != nil {
+		return cfg
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg
+	}
+
+	var loaded uiConfig
+	if err := yaml.Unmarshal(data, &loaded); err != nil {
+		return cfg
+	}
+	if loaded.SplitRatio > 0 && loaded.SplitRatio < 1 {
+		cfg.SplitRatio = loaded.SplitRatio
+	}
+	cfg.PreviewHidden = loaded.PreviewHidden
+	return cfg
+}
+
+func saveUIConfig(cfg uiConfig) error {
+	path, err := uiConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,49 @@
+package main
+
+import "github.com/gdamore/tcell/v2"
+
+func ensureVisible(cursor, offset, viewHeight, total int) int {
+	if viewHeight <= 0 {
+		return 0
+	}
+	maxOffset := max(0, total-viewHeight)
+	if cursor < offset {
+		offset = cursor
+	} else if cursor >= offset+viewHeight {
+		offset = cursor - viewHeight + 1
+	}
+	if offset > maxOffset {
+		offset = maxOffset
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// drawLine fills the full screen width of row with text.
+func drawLine(screen tcell.Screen, row int, text string, style tcell.Style) {
+	width, _ := screen.Size()
+	drawLineRange(screen, row, text, style, 0, width)
+}
+
+// drawLineRange fills columns [colStart, colEnd) of row with text, padding
+// with spaces past the end of text.
+func drawLineRange(screen tcell.Screen, row int, text string, style tcell.Style, colStart, colEnd int) {
+	runes := []rune(text)
+	for col := colStart; col < colEnd; col++ {
+		ch := ' '
+		idx := col - colStart
+		if idx < len(runes) {
+			ch = runes[idx]
+		}
+		screen.SetContent(col, row, ch, nil, style)
+	}
+}
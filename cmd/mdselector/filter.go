@@ -0,0 +1,55 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/gq97a6/md_selector/pkg/selector"
+	"github.com/gq97a6/md_selector/pkg/selector/fuzzy"
+)
+
+// filterMatch is one leaf node surviving a fuzzy query, with the matched
+// rune positions used to highlight its name.
+type filterMatch struct {
+	node      *selector.Node
+	positions []int
+	score     int
+}
+
+// filterLeaves fuzzy-matches query against every leaf under root and
+// returns the survivors sorted by score, descending. Checked state lives
+// on the tree itself, so it stays stable as the query changes.
+func filterLeaves(root *selector.Node, query string) []filterMatch {
+	var matches []filterMatch
+	var visit func(n *selector.Node)
+	visit = func(n *selector.Node) {
+		if !n.IsDir {
+			if score, positions, ok := fuzzy.Score(query, n.Item.Name); ok {
+				matches = append(matches, filterMatch{node: n, positions: positions, score: score})
+			}
+			return
+		}
+		for _, c := range n.Children {
+			visit(c)
+		}
+	}
+	visit(root)
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	return matches
+}
+
+func flattenMatches(matches []filterMatch) []selector.FlatNode {
+	out := make([]selector.FlatNode, len(matches))
+	for i, m := range matches {
+		out[i] = selector.FlatNode{Node: m.node, Depth: 0}
+	}
+	return out
+}
+
+func setMatchesChecked(matches []filterMatch, checked bool) {
+	for _, m := range matches {
+		m.node.Item.SetChecked(checked)
+	}
+}
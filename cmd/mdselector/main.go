@@ -0,0 +1,139 @@
+// Command mdselector presents an interactive checklist of the Markdown
+// files under a directory and writes the chosen selection in the --format
+// encoding to the --output file (output.txt by default).
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gq97a6/md_selector/pkg/selector"
+	"github.com/gq97a6/md_selector/pkg/selector/rpc"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <path-with-markdown-files>\n", filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
+	rpcMode := flag.Bool("rpc", false, "speak newline-delimited JSON-RPC 2.0 over stdio instead of opening a terminal UI")
+	maxDepth := flag.Int("max-depth", 0, "limit the recursive directory scan to this many levels (0 = unlimited)")
+	format := flag.String("format", "plain", "output format: plain|json|yaml|markdown|recfile")
+	output := flag.String("output", "", "output file path (default: output.txt in the current directory)")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	target := flag.Arg(0)
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		exitErr(err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		exitErr(err)
+	}
+	store := selector.NewFileStore(filepath.Join(cwd, "output.txt"))
+	checked, err := store.Load()
+	if err != nil {
+		exitErr(err)
+	}
+
+	if *rpcMode {
+		runRPC(absTarget, *maxDepth, store, checked)
+		return
+	}
+
+	writer, err := selector.WriterFor(*format)
+	if err != nil {
+		exitErr(err)
+	}
+	outputPath := *output
+	if outputPath == "" {
+		outputPath = filepath.Join(cwd, "output.txt")
+	}
+
+	runTree(absTarget, *maxDepth, store, checked, writer, outputPath)
+}
+
+// runRPC recursively scans absTarget, the same as the tree UI, and serves
+// the JSON-RPC control mode over stdio.
+func runRPC(absTarget string, maxDepth int, store selector.Store, checked map[string]bool) {
+	fsys := selector.OSFS{}
+	root, err := selector.Walk(fsys, absTarget, maxDepth)
+	if err != nil {
+		exitErr(err)
+	}
+	var items []selector.Item
+	if root != nil {
+		if err := selector.ApplyCheckedTree(root, checked); err != nil {
+			exitErr(err)
+		}
+		items = selector.Leaves(root)
+	}
+
+	model := selector.NewModel(items, selector.KeyMap{})
+	server := rpc.NewServer(model, store, os.Stdout)
+	if err := server.Serve(os.Stdin); err != nil {
+		exitErr(err)
+	}
+}
+
+// runTree recursively scans absTarget and drives the two-pane tree UI. The
+// chosen selection is exported through writer to outputPath, while store
+// separately tracks resume state at the default output.txt regardless of
+// the requested --format/--output.
+func runTree(absTarget string, maxDepth int, store selector.Store, checked map[string]bool, writer selector.Writer, outputPath string) {
+	fsys := selector.OSFS{}
+	root, err := selector.Walk(fsys, absTarget, maxDepth)
+	if err != nil {
+		exitErr(err)
+	}
+	if root == nil {
+		fmt.Println("No Markdown files found in", absTarget)
+		return
+	}
+	if err := selector.ApplyCheckedTree(root, checked); err != nil {
+		exitErr(err)
+	}
+
+	finalItems, aborted, err := runTreeSelector(fsys, root)
+	if err != nil {
+		exitErr(err)
+	}
+	if aborted {
+		fmt.Println("Selection aborted.")
+		return
+	}
+
+	count, err := writer.Write(outputPath, absTarget, finalItems)
+	if err != nil {
+		exitErr(err)
+	}
+	if _, err := store.Save(finalItems); err != nil {
+		exitErr(err)
+	}
+
+	if count == 0 {
+		fmt.Println("Wrote empty selection to", outputPath)
+	} else {
+		fmt.Printf("Saved %d selection(s) to %s\n", count, outputPath)
+	}
+}
+
+func exitErr(err error) {
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		fmt.Fprintf(os.Stderr, "Path error: %v\n", pathErr)
+	} else {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	os.Exit(1)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// styledLine is one line of rendered preview text paired with the style it
+// should be drawn in.
+type styledLine struct {
+	text  string
+	style tcell.Style
+}
+
+// renderMarkdown applies light syntax-aware styling to Markdown preview
+// lines: headings are bold, fenced code blocks are dimmed with a
+// background, and list items are indented.
+func renderMarkdown(lines []string) []styledLine {
+	fenceStyle := tcell.StyleDefault.Dim(true).Background(tcell.ColorDarkSlateGray)
+
+	out := make([]styledLine, len(lines))
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			out[i] = styledLine{line, fenceStyle}
+			continue
+		}
+		if inFence {
+			out[i] = styledLine{line, fenceStyle}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			out[i] = styledLine{line, tcell.StyleDefault.Bold(true)}
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "), strings.HasPrefix(trimmed, "+ "):
+			out[i] = styledLine{"  " + line, tcell.StyleDefault}
+		default:
+			out[i] = styledLine{line, tcell.StyleDefault}
+		}
+	}
+	return out
+}
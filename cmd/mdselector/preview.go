@@ -0,0 +1,65 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/gq97a6/md_selector/pkg/selector"
+)
+
+// previewRefreshDelay debounces preview re-renders so holding down j/k
+// doesn't re-read a file on every intermediate cursor position.
+const previewRefreshDelay = 50 * time.Millisecond
+
+// previewRefreshEvent is posted to the tcell screen once a debounced
+// preview read completes, so the main loop knows to redraw.
+type previewRefreshEvent struct{ at time.Time }
+
+func (e *previewRefreshEvent) When() time.Time { return e.at }
+
+// previewCache holds the most recently rendered preview, updated on a
+// debounce timer so rapid cursor movement doesn't thrash disk.
+type previewCache struct {
+	mu    sync.Mutex
+	path  string
+	lines []styledLine
+	timer *time.Timer
+}
+
+// request schedules a (re-)read of path, height lines deep, previewRefreshDelay
+// from now, replacing any pending read. When the read completes, it posts a
+// previewRefreshEvent to screen so the caller can redraw.
+func (c *previewCache) request(screen tcell.Screen, fsys selector.FS, path string, height int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+	c.timer = time.AfterFunc(previewRefreshDelay, func() {
+		lines, err := selector.PreviewLines(fsys, path, height)
+		rendered := renderMarkdown(lines)
+		if err != nil {
+			rendered = []styledLine{{text: "preview error: " + err.Error()}}
+		}
+
+		c.mu.Lock()
+		c.path, c.lines = path, rendered
+		c.mu.Unlock()
+
+		screen.PostEvent(&previewRefreshEvent{at: time.Now()})
+	})
+}
+
+// get returns the cached lines for path, or nil if nothing has loaded for
+// it yet.
+func (c *previewCache) get(path string) []styledLine {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.path != path {
+		return nil
+	}
+	return c.lines
+}
@@ -0,0 +1,398 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/gq97a6/md_selector/pkg/selector"
+)
+
+// runTreeSelector drives the two-pane tree UI: a collapsible directory
+// tree with tri-state checkboxes on the left, and a preview of the
+// highlighted *.md file on the right. Pressing "/" opens a query line that
+// fuzzy-filters the visible leaves. The split ratio and whether the
+// preview pane is shown persist to $XDG_CONFIG_HOME/mdselector/config.yaml.
+func runTreeSelector(fsys selector.FS, root *selector.Node) ([]selector.Item, bool, error) {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return nil, false, err
+	}
+	if err := screen.Init(); err != nil {
+		return nil, false, err
+	}
+	defer screen.Fini()
+	screen.SetStyle(tcell.StyleDefault)
+
+	cfg := loadUIConfig()
+	defer func() { _ = saveUIConfig(cfg) }()
+
+	preview := &previewCache{}
+	lastPreviewPath := ""
+
+	cursor, offset := 0, 0
+	filtering := false
+	anchor := -1
+	var query []rune
+	var filtered []filterMatch
+
+	for {
+		var nodes []selector.FlatNode
+		if filtering || filtered != nil {
+			nodes = flattenMatches(filtered)
+		} else {
+			nodes = selector.Flatten(root)
+		}
+		if cursor > len(nodes)-1 {
+			cursor = len(nodes) - 1
+		}
+		if cursor < 0 {
+			cursor = 0
+		}
+
+		instructions := treeInstructions(filtering, string(query), cfg)
+		listHeight := drawTreeScreen(screen, nodes, cursor, offset, instructions, filtered, cfg, preview)
+
+		if len(nodes) > 0 && !cfg.PreviewHidden {
+			currentPath := nodes[cursor].Node.Path
+			if currentPath != lastPreviewPath {
+				lastPreviewPath = currentPath
+				preview.request(screen, fsys, currentPath, listHeight)
+			}
+		}
+
+		ev := screen.PollEvent()
+
+		if _, isRefresh := ev.(*previewRefreshEvent); isRefresh {
+			continue
+		}
+
+		event, isKey := ev.(*tcell.EventKey)
+		if !isKey {
+			if _, isResize := ev.(*tcell.EventResize); isResize {
+				screen.Sync()
+			}
+			continue
+		}
+
+		if filtering {
+			switch event.Key() {
+			case tcell.KeyEscape:
+				filtering, query, filtered = false, nil, nil
+			case tcell.KeyEnter:
+				filtering = false
+			case tcell.KeyBackspace, tcell.KeyBackspace2:
+				if len(query) > 0 {
+					query = query[:len(query)-1]
+				}
+				filtered, cursor = filterLeaves(root, string(query)), 0
+			case tcell.KeyCtrlA:
+				setMatchesChecked(filtered, true)
+			case tcell.KeyCtrlN:
+				setMatchesChecked(filtered, false)
+			case tcell.KeyRune:
+				query = append(query, event.Rune())
+				filtered, cursor = filterLeaves(root, string(query)), 0
+			}
+			offset = ensureVisible(cursor, offset, listHeight, len(nodes))
+			continue
+		}
+
+		switch event.Key() {
+		case tcell.KeyEscape:
+			if filtered != nil {
+				filtered, query, cursor = nil, nil, 0
+				break
+			}
+			return nil, true, nil
+		case tcell.KeyCtrlC:
+			return nil, true, nil
+		case tcell.KeyEnter:
+			return selector.Leaves(root), false, nil
+		case tcell.KeyUp:
+			cursor = moveCursor(cursor, -1, len(nodes))
+		case tcell.KeyDown:
+			cursor = moveCursor(cursor, 1, len(nodes))
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'q', 'Q':
+				return nil, true, nil
+			case 'k':
+				cursor = moveCursor(cursor, -1, len(nodes))
+			case 'j':
+				cursor = moveCursor(cursor, 1, len(nodes))
+			case 'h':
+				if len(nodes) > 0 && nodes[cursor].Node.IsDir {
+					nodes[cursor].Node.Expanded = false
+				}
+			case 'l':
+				if len(nodes) > 0 && nodes[cursor].Node.IsDir {
+					nodes[cursor].Node.Expanded = true
+				}
+			case ' ':
+				if len(nodes) > 0 && !nodes[cursor].Node.IsDir {
+					if anchor >= 0 {
+						toggleNodeRange(nodes, anchor, cursor)
+						anchor = -1
+					} else {
+						nodes[cursor].Node.Item.SetChecked(!nodes[cursor].Node.Item.Checked)
+					}
+				}
+			case 'X':
+				if len(nodes) > 0 {
+					recursiveCheck(nodes[cursor].Node)
+				}
+			case 'a':
+				setNodesChecked(nodes, true)
+			case 'A':
+				setNodesChecked(nodes, false)
+			case 'i':
+				invertNodes(nodes)
+			case 'v':
+				if anchor >= 0 {
+					anchor = -1
+				} else {
+					anchor = cursor
+				}
+			case '/':
+				filtering, query = true, nil
+				filtered, cursor = filterLeaves(root, ""), 0
+			case 'p':
+				cfg.PreviewHidden = !cfg.PreviewHidden
+			case '<':
+				cfg.SplitRatio = clampRatio(cfg.SplitRatio - 0.02)
+			case '>':
+				cfg.SplitRatio = clampRatio(cfg.SplitRatio + 0.02)
+			}
+		}
+		offset = ensureVisible(cursor, offset, listHeight, len(nodes))
+	}
+}
+
+func treeInstructions(filtering bool, query string, cfg uiConfig) string {
+	if filtering {
+		return fmt.Sprintf("/%s  (type to filter • enter apply • esc clear • ^a/^n check all/none)", query)
+	}
+	preview := "p hide preview"
+	if cfg.PreviewHidden {
+		preview = "p show preview"
+	}
+	return fmt.Sprintf("↑/↓ move • h/l fold • space toggle • X recursive • a/A all/none • i invert • v mark range • / filter • </> resize • %s • enter save • q/Esc cancel", preview)
+}
+
+func clampRatio(ratio float64) float64 {
+	switch {
+	case ratio < 0.2:
+		return 0.2
+	case ratio > 0.8:
+		return 0.8
+	default:
+		return ratio
+	}
+}
+
+func moveCursor(cursor, delta, total int) int {
+	cursor += delta
+	if cursor < 0 {
+		cursor = 0
+	}
+	if total > 0 && cursor > total-1 {
+		cursor = total - 1
+	}
+	return cursor
+}
+
+// recursiveCheck toggles every leaf under n based on n's current aggregate
+// state: a Checked node becomes fully unchecked, an Unchecked or Partial
+// node becomes fully checked.
+func recursiveCheck(n *selector.Node) {
+	n.SetChecked(n.State() != selector.Checked)
+}
+
+// setNodesChecked sets the checked state of every leaf among the currently
+// visible nodes, skipping directory rows (their state is derived, not
+// stored).
+func setNodesChecked(nodes []selector.FlatNode, checked bool) {
+	for _, fn := range nodes {
+		if fn.Node.IsDir {
+			continue
+		}
+		fn.Node.Item.SetChecked(checked)
+	}
+}
+
+// invertNodes flips the checked state of every leaf among the currently
+// visible nodes.
+func invertNodes(nodes []selector.FlatNode) {
+	for _, fn := range nodes {
+		if fn.Node.IsDir {
+			continue
+		}
+		fn.Node.Item.SetChecked(!fn.Node.Item.Checked)
+	}
+}
+
+// toggleNodeRange toggles every leaf between from and to (inclusive, order
+// independent) among nodes to the same checked state: checked if any leaf
+// in the range is currently unchecked, unchecked if they already all are.
+// Directory rows within the range are left alone.
+func toggleNodeRange(nodes []selector.FlatNode, from, to int) {
+	if len(nodes) == 0 {
+		return
+	}
+	if from > to {
+		from, to = to, from
+	}
+	if from < 0 {
+		from = 0
+	}
+	if to > len(nodes)-1 {
+		to = len(nodes) - 1
+	}
+
+	target := false
+	for i := from; i <= to; i++ {
+		if n := nodes[i].Node; !n.IsDir && !n.Item.Checked {
+			target = true
+			break
+		}
+	}
+	for i := from; i <= to; i++ {
+		if n := nodes[i].Node; !n.IsDir {
+			n.Item.SetChecked(target)
+		}
+	}
+}
+
+func drawTreeScreen(screen tcell.Screen, nodes []selector.FlatNode, cursor, offset int, instructions string, filtered []filterMatch, cfg uiConfig, preview *previewCache) int {
+	screen.Clear()
+	width, height := screen.Size()
+	headerLines := 2
+	listHeight := height - headerLines
+	if listHeight < 1 {
+		listHeight = 1
+	}
+
+	drawLine(screen, 0, instructions, tcell.StyleDefault)
+	drawLine(screen, 1, strings.Repeat("-", max(0, width)), tcell.StyleDefault)
+
+	leftWidth := width
+	if !cfg.PreviewHidden {
+		leftWidth = int(float64(width) * cfg.SplitRatio)
+		if leftWidth < 20 || leftWidth >= width {
+			leftWidth = width
+		}
+	}
+
+	if len(nodes) == 0 {
+		drawLine(screen, headerLines, "No matches.", tcell.StyleDefault)
+		screen.Show()
+		return listHeight
+	}
+
+	posByNode := make(map[*selector.Node][]int, len(filtered))
+	for _, m := range filtered {
+		posByNode[m.node] = m.positions
+	}
+
+	if offset > len(nodes)-listHeight {
+		offset = max(0, len(nodes)-listHeight)
+	}
+	end := offset + listHeight
+	if end > len(nodes) {
+		end = len(nodes)
+	}
+
+	row := headerLines
+	for i := offset; i < end; i++ {
+		fn := nodes[i]
+		drawNodeLine(screen, row, fn, cursor == i, posByNode[fn.Node], 0, leftWidth)
+		row++
+	}
+
+	if leftWidth < width {
+		drawPreview(screen, nodes[cursor].Node, preview, headerLines, leftWidth+1, width, listHeight)
+	}
+
+	screen.Show()
+	return listHeight
+}
+
+// nodePrefix renders everything before a node's name: cursor indicator,
+// indent, expand arrow (directories only), and checkbox.
+func nodePrefix(fn selector.FlatNode, active bool) string {
+	indicator := " "
+	if active {
+		indicator = ">"
+	}
+	indent := strings.Repeat("  ", fn.Depth)
+	box := stateBox(fn.Node.State())
+
+	if fn.Node.IsDir {
+		arrow := "▸"
+		if fn.Node.Expanded {
+			arrow = "▾"
+		}
+		return fmt.Sprintf("%s%s%s %s ", indicator, indent, arrow, box)
+	}
+	return fmt.Sprintf("%s%s  %s ", indicator, indent, box)
+}
+
+// drawNodeLine renders one tree row, bolding the rune positions a fuzzy
+// filter matched in the node's name and dimming the rest of the name when
+// positions is non-nil (i.e. the row came from a filtered view).
+func drawNodeLine(screen tcell.Screen, row int, fn selector.FlatNode, active bool, positions []int, colStart, colEnd int) {
+	prefix := nodePrefix(fn, active)
+	full := prefix + fn.Node.Name
+	runes := []rune(full)
+	nameStart := len([]rune(prefix))
+
+	matchSet := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		matchSet[nameStart+p] = true
+	}
+
+	for col := colStart; col < colEnd; col++ {
+		idx := col - colStart
+		ch := rune(' ')
+		style := tcell.StyleDefault
+		if idx < len(runes) {
+			ch = runes[idx]
+			switch {
+			case matchSet[idx]:
+				style = style.Bold(true)
+			case positions != nil && idx >= nameStart:
+				style = style.Dim(true)
+			}
+		}
+		screen.SetContent(col, row, ch, nil, style)
+	}
+}
+
+func stateBox(s selector.CheckState) string {
+	switch s {
+	case selector.Checked:
+		return "[x]"
+	case selector.Partial:
+		return "[~]"
+	default:
+		return "[ ]"
+	}
+}
+
+func drawPreview(screen tcell.Screen, n *selector.Node, preview *previewCache, row, colStart, colEnd, height int) {
+	if n.IsDir {
+		drawLineRange(screen, row, "(directory)", tcell.StyleDefault, colStart, colEnd)
+		return
+	}
+
+	lines := preview.get(n.Path)
+	for i := 0; i < height; i++ {
+		var line styledLine
+		if i < len(lines) {
+			line = lines[i]
+		}
+		drawLineRange(screen, row+i, line.text, line.style, colStart, colEnd)
+	}
+}
@@ -0,0 +1,28 @@
+package selector
+
+import (
+	"io"
+	"io/fs"
+	"os"
+)
+
+// FS abstracts the filesystem Walk scans, modeled after afero.Fs: a small
+// interface real filesystems, in-memory trees, or remote backends can all
+// implement so tests can mount a fixture tree without touching disk.
+type FS interface {
+	ReadDir(name string) ([]fs.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+	// Open returns a stream for name, so callers that only need the first
+	// few lines (like a preview pane) don't have to read an entire large
+	// file into memory.
+	Open(name string) (io.ReadCloser, error)
+}
+
+// OSFS implements FS on top of the local filesystem.
+type OSFS struct{}
+
+func (OSFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+func (OSFS) ReadFile(name string) ([]byte, error)       { return os.ReadFile(name) }
+func (OSFS) Open(name string) (io.ReadCloser, error)    { return os.Open(name) }
+
+var _ FS = OSFS{}
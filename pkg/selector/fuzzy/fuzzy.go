@@ -0,0 +1,134 @@
+// Package fuzzy implements subsequence fuzzy matching for incremental
+// filtering, in the style of fzf/Sublime's command palette matchers.
+package fuzzy
+
+import "strings"
+
+// Tuning constants for Score's dynamic program.
+const (
+	scoreMatch       = 16
+	scoreGapPenalty  = -3
+	bonusBoundary    = 10
+	bonusCamel       = 8
+	bonusConsecutive = 6
+)
+
+const negInf = -1 << 30
+
+// Score performs a case-insensitive subsequence match of pattern against
+// candidate. It returns the match score, the matched rune positions in
+// candidate (for highlighting), and whether every pattern rune was found
+// in order. An empty pattern trivially matches with score 0.
+//
+// M[i][j] holds the best score for matching the first i runes of pattern
+// against the first j runes of candidate, with pattern rune i-1 landing on
+// candidate rune j-1. run[i][j] is the length of the consecutive-match
+// streak ending at that cell, used to add bonusConsecutive for runs and
+// scoreGapPenalty per skipped candidate rune otherwise. from[i][j] records
+// which column in row i-1 produced the best M[i][j], so match positions
+// are recovered by backtracking once the table is filled.
+func Score(pattern, candidate string) (score int, positions []int, ok bool) {
+	p := []rune(strings.ToLower(pattern))
+	c := []rune(candidate)
+	cl := []rune(strings.ToLower(candidate))
+	n, m := len(p), len(c)
+
+	if n == 0 {
+		return 0, nil, true
+	}
+	if m < n {
+		return 0, nil, false
+	}
+
+	M := make([][]int, n+1)
+	run := make([][]int, n+1)
+	from := make([][]int, n+1)
+	for i := range M {
+		M[i] = make([]int, m+1)
+		run[i] = make([]int, m+1)
+		from[i] = make([]int, m+1)
+		for j := range M[i] {
+			M[i][j] = negInf
+			from[i][j] = -1
+		}
+	}
+	for j := 0; j <= m; j++ {
+		M[0][j] = 0
+	}
+
+	for i := 1; i <= n; i++ {
+		for j := i; j <= m; j++ {
+			if cl[j-1] != p[i-1] {
+				continue
+			}
+
+			bonus := matchBonus(c, j)
+			best, bestFrom, bestRun := negInf, -1, 0
+			for k := i - 1; k < j; k++ {
+				if M[i-1][k] == negInf {
+					continue
+				}
+				gap := j - k - 1
+				r, extra := 1, gap*scoreGapPenalty
+				if gap == 0 {
+					r = run[i-1][k] + 1
+					extra = r * bonusConsecutive
+				}
+				if cand := M[i-1][k] + scoreMatch + bonus + extra; cand > best {
+					best, bestFrom, bestRun = cand, k, r
+				}
+			}
+			M[i][j], from[i][j], run[i][j] = best, bestFrom, bestRun
+		}
+	}
+
+	bestScore, bestJ := negInf, -1
+	for j := n; j <= m; j++ {
+		if M[n][j] > bestScore {
+			bestScore, bestJ = M[n][j], j
+		}
+	}
+	if bestJ == -1 {
+		return 0, nil, false
+	}
+
+	positions = make([]int, n)
+	i, j := n, bestJ
+	for i > 0 {
+		positions[i-1] = j - 1
+		j = from[i][j]
+		i--
+	}
+
+	return bestScore, positions, true
+}
+
+func matchBonus(c []rune, j int) int {
+	if j == 1 {
+		return bonusBoundary
+	}
+	prev, cur := c[j-2], c[j-1]
+	switch {
+	case isBoundary(prev, cur):
+		return bonusBoundary
+	case isCamel(prev, cur):
+		return bonusCamel
+	default:
+		return 0
+	}
+}
+
+func isWordChar(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+func isBoundary(prev, cur rune) bool {
+	return !isWordChar(prev) && isWordChar(cur)
+}
+
+func isUpper(r rune) bool { return r >= 'A' && r <= 'Z' }
+func isLower(r rune) bool { return r >= 'a' && r <= 'z' }
+
+func isCamel(prev, cur rune) bool {
+	return isLower(prev) && isUpper(cur)
+}
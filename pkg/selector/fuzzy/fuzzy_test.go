@@ -0,0 +1,42 @@
+package fuzzy
+
+import "testing"
+
+func TestScoreSubsequence(t *testing.T) {
+	score, positions, ok := Score("mds", "md_selector")
+	if !ok {
+		t.Fatalf("expected a match")
+	}
+	if len(positions) != 3 {
+		t.Fatalf("expected 3 positions, got %v", positions)
+	}
+	for i := 1; i < len(positions); i++ {
+		if positions[i] <= positions[i-1] {
+			t.Fatalf("positions must be strictly increasing, got %v", positions)
+		}
+	}
+	if score <= 0 {
+		t.Fatalf("expected a positive score, got %d", score)
+	}
+}
+
+func TestScoreNoMatch(t *testing.T) {
+	if _, _, ok := Score("xyz", "abc"); ok {
+		t.Fatalf("expected no match")
+	}
+}
+
+func TestScorePrefersConsecutiveRuns(t *testing.T) {
+	consecutive, _, _ := Score("sel", "selector")
+	scattered, _, _ := Score("sel", "s_e_l_ector")
+	if consecutive <= scattered {
+		t.Fatalf("expected consecutive match to score higher: %d vs %d", consecutive, scattered)
+	}
+}
+
+func TestScoreEmptyPattern(t *testing.T) {
+	score, positions, ok := Score("", "anything")
+	if !ok || score != 0 || positions != nil {
+		t.Fatalf("empty pattern should trivially match with no positions, got score=%d positions=%v ok=%v", score, positions, ok)
+	}
+}
@@ -0,0 +1,243 @@
+// Package selector implements the headless state machine behind mdselector:
+// an item list with a cursor, toggle/range/all/invert commands, and key
+// binding dispatch, all independent of any terminal so it can be embedded
+// in other TUIs or driven from tests without a real screen.
+package selector
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Item is a single selectable entry in the list.
+type Item struct {
+	Name      string
+	Path      string // absolute path to the source .md file, when known
+	Checked   bool
+	CheckedAt time.Time // zero unless Checked is true
+}
+
+// SetChecked sets the item's checked state, stamping CheckedAt with the
+// current time when it becomes checked and clearing it otherwise.
+func (it *Item) SetChecked(checked bool) {
+	it.Checked = checked
+	if checked {
+		it.CheckedAt = time.Now()
+	} else {
+		it.CheckedAt = time.Time{}
+	}
+}
+
+// KeyMap maps tcell key events to Model actions. The zero value is
+// replaced with DefaultKeyMap by NewModel.
+type KeyMap struct {
+	Up         []rune
+	Down       []rune
+	Toggle     []rune
+	Quit       []rune
+	SelectAll  []rune
+	SelectNone []rune
+	Invert     []rune
+	MarkRange  []rune
+}
+
+// DefaultKeyMap mirrors the bindings shown in cmd/mdselector's status line.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:         []rune{'k'},
+		Down:       []rune{'j'},
+		Toggle:     []rune{' '},
+		Quit:       []rune{'q', 'Q'},
+		SelectAll:  []rune{'a'},
+		SelectNone: []rune{'A'},
+		Invert:     []rune{'i'},
+		MarkRange:  []rune{'v'},
+	}
+}
+
+func (k KeyMap) matches(set []rune, r rune) bool {
+	for _, c := range set {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Model is the headless selector state machine. It owns the item list,
+// cursor, and range-marking state, and is driven through HandleKey or its
+// exported commands (SetChecked, SetAll, Invert, ToggleRange) so it can be
+// exercised without a real terminal.
+type Model struct {
+	items  []Item
+	cursor int
+	anchor int // range anchor set by MarkRange; -1 when no range is marked
+	keys   KeyMap
+
+	done    bool
+	aborted bool
+}
+
+// NewModel builds a Model over items using the given KeyMap. A KeyMap with
+// a nil Toggle is treated as unset and replaced with DefaultKeyMap.
+func NewModel(items []Item, keys KeyMap) *Model {
+	if keys.Toggle == nil {
+		keys = DefaultKeyMap()
+	}
+	return &Model{
+		items:  append([]Item(nil), items...),
+		anchor: -1,
+		keys:   keys,
+	}
+}
+
+// Items returns a copy of the current item list.
+func (m *Model) Items() []Item {
+	out := make([]Item, len(m.items))
+	copy(out, m.items)
+	return out
+}
+
+// Cursor returns the index of the currently highlighted item.
+func (m *Model) Cursor() int { return m.cursor }
+
+// Done reports whether the model has reached a terminal state (confirmed
+// or aborted) and, if so, whether the user aborted rather than confirmed.
+func (m *Model) Done() (done, aborted bool) { return m.done, m.aborted }
+
+// HandleKey applies a single key event to the model and reports whether it
+// recognized the key.
+func (m *Model) HandleKey(ev *tcell.EventKey) bool {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyCtrlC:
+		m.done, m.aborted = true, true
+		return true
+	case tcell.KeyEnter:
+		m.done = true
+		return true
+	case tcell.KeyUp:
+		m.moveCursor(-1)
+		return true
+	case tcell.KeyDown:
+		m.moveCursor(1)
+		return true
+	case tcell.KeyRune:
+		return m.handleRune(ev.Rune())
+	}
+	return false
+}
+
+func (m *Model) handleRune(r rune) bool {
+	switch {
+	case m.keys.matches(m.keys.Quit, r):
+		m.done, m.aborted = true, true
+	case m.keys.matches(m.keys.Up, r):
+		m.moveCursor(-1)
+	case m.keys.matches(m.keys.Down, r):
+		m.moveCursor(1)
+	case m.keys.matches(m.keys.Toggle, r):
+		m.toggleAtCursor()
+	case m.keys.matches(m.keys.SelectAll, r):
+		m.SetAll(true)
+	case m.keys.matches(m.keys.SelectNone, r):
+		m.SetAll(false)
+	case m.keys.matches(m.keys.Invert, r):
+		m.Invert()
+	case m.keys.matches(m.keys.MarkRange, r):
+		m.toggleRangeMark()
+	default:
+		return false
+	}
+	return true
+}
+
+func (m *Model) moveCursor(delta int) {
+	if len(m.items) == 0 {
+		return
+	}
+	m.cursor += delta
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	if m.cursor > len(m.items)-1 {
+		m.cursor = len(m.items) - 1
+	}
+}
+
+func (m *Model) toggleAtCursor() {
+	if m.anchor >= 0 {
+		m.ToggleRange(m.anchor, m.cursor)
+		m.anchor = -1
+		return
+	}
+	if len(m.items) == 0 {
+		return
+	}
+	m.SetChecked(m.cursor, !m.items[m.cursor].Checked)
+}
+
+func (m *Model) toggleRangeMark() {
+	if m.anchor >= 0 {
+		m.anchor = -1
+		return
+	}
+	m.anchor = m.cursor
+}
+
+// ToggleRange toggles every item between from and to (inclusive, order
+// independent) to the same checked state: checked if any item in the range
+// is currently unchecked, unchecked if they already all are.
+func (m *Model) ToggleRange(from, to int) {
+	if len(m.items) == 0 {
+		return
+	}
+	if from > to {
+		from, to = to, from
+	}
+	from, to = clamp(from, 0, len(m.items)-1), clamp(to, 0, len(m.items)-1)
+
+	target := false
+	for i := from; i <= to; i++ {
+		if !m.items[i].Checked {
+			target = true
+			break
+		}
+	}
+	for i := from; i <= to; i++ {
+		m.SetChecked(i, target)
+	}
+}
+
+// SetChecked sets the checked state of the item at index, stamping
+// CheckedAt when it becomes checked and clearing it otherwise.
+func (m *Model) SetChecked(index int, checked bool) {
+	if index < 0 || index >= len(m.items) {
+		return
+	}
+	m.items[index].SetChecked(checked)
+}
+
+// SetAll sets every item's checked state to checked.
+func (m *Model) SetAll(checked bool) {
+	for i := range m.items {
+		m.items[i].SetChecked(checked)
+	}
+}
+
+// Invert flips the checked state of every item.
+func (m *Model) Invert() {
+	for i := range m.items {
+		m.items[i].SetChecked(!m.items[i].Checked)
+	}
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
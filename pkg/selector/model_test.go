@@ -0,0 +1,70 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func key(r rune) *tcell.EventKey {
+	return tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)
+}
+
+func special(k tcell.Key) *tcell.EventKey {
+	return tcell.NewEventKey(k, 0, tcell.ModNone)
+}
+
+func TestToggleAndMove(t *testing.T) {
+	m := NewModel([]Item{{Name: "a"}, {Name: "b"}, {Name: "c"}}, KeyMap{})
+
+	m.HandleKey(key(' '))
+	if !m.Items()[0].Checked {
+		t.Fatalf("expected item 0 to be checked")
+	}
+
+	m.HandleKey(key('j'))
+	if m.Cursor() != 1 {
+		t.Fatalf("expected cursor at 1, got %d", m.Cursor())
+	}
+
+	m.HandleKey(special(tcell.KeyEnter))
+	if done, aborted := m.Done(); !done || aborted {
+		t.Fatalf("expected confirmed completion, got done=%v aborted=%v", done, aborted)
+	}
+}
+
+func TestSelectAllInvertRange(t *testing.T) {
+	m := NewModel([]Item{{Name: "a"}, {Name: "b"}, {Name: "c"}}, KeyMap{})
+
+	m.HandleKey(key('a'))
+	for _, it := range m.Items() {
+		if !it.Checked {
+			t.Fatalf("expected all items checked after select-all")
+		}
+	}
+
+	m.HandleKey(key('i'))
+	for _, it := range m.Items() {
+		if it.Checked {
+			t.Fatalf("expected all items unchecked after invert")
+		}
+	}
+
+	m.HandleKey(key('v'))
+	m.HandleKey(key('j'))
+	m.HandleKey(key('j'))
+	m.HandleKey(key(' '))
+	for i, it := range m.Items() {
+		if !it.Checked {
+			t.Fatalf("expected item %d checked after ranged toggle", i)
+		}
+	}
+}
+
+func TestAbort(t *testing.T) {
+	m := NewModel([]Item{{Name: "a"}}, KeyMap{})
+	m.HandleKey(special(tcell.KeyEscape))
+	if done, aborted := m.Done(); !done || !aborted {
+		t.Fatalf("expected aborted completion, got done=%v aborted=%v", done, aborted)
+	}
+}
@@ -0,0 +1,26 @@
+package selector
+
+import "bufio"
+
+// PreviewLines reads up to n lines from the start of the file at path on
+// fsys. It streams through fsys.Open rather than reading the whole file,
+// so opening a huge file to preview a handful of lines doesn't stall the
+// caller.
+func PreviewLines(fsys FS, path string, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	file, err := fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for len(lines) < n && scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
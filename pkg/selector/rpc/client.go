@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/gq97a6/md_selector/pkg/selector"
+)
+
+// Client drives a Server over a pair of pipes (for example an
+// *os/exec.Cmd's Stdin/Stdout), matching the request/response pattern used
+// by LSP clients: every call blocks for the response carrying its ID,
+// skipping over any notifications in between.
+type Client struct {
+	w       io.Writer
+	scanner *bufio.Scanner
+
+	mu     sync.Mutex
+	nextID int64
+}
+
+// NewClient returns a Client writing requests to w and reading responses
+// from r.
+func NewClient(w io.Writer, r io.Reader) *Client {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	return &Client{w: w, scanner: scanner}
+}
+
+// Call sends method with params and returns the raw JSON result, or an
+// error built from the response's error object.
+func (c *Client) Call(method string, params interface{}) (json.RawMessage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nextID++
+	id := json.RawMessage(strconv.FormatInt(c.nextID, 10))
+
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	line, err := json.Marshal(Request{JSONRPC: "2.0", ID: &id, Method: method, Params: paramsJSON})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.w.Write(append(line, '\n')); err != nil {
+		return nil, err
+	}
+
+	for c.scanner.Scan() {
+		var resp Response
+		if err := json.Unmarshal(c.scanner.Bytes(), &resp); err != nil {
+			return nil, err
+		}
+		if resp.ID == nil || string(*resp.ID) != string(id) {
+			continue // a notification, or the response to an earlier call
+		}
+		if resp.Error != nil {
+			return nil, fmt.Errorf("rpc: %s", resp.Error.Message)
+		}
+		return resp.Result, nil
+	}
+	if err := c.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+// List returns the current item list.
+func (c *Client) List() ([]selector.Item, error) {
+	raw, err := c.Call("list", nil)
+	if err != nil {
+		return nil, err
+	}
+	var items []selector.Item
+	err = json.Unmarshal(raw, &items)
+	return items, err
+}
+
+// Toggle flips the checked state of the item at index.
+func (c *Client) Toggle(index int) ([]selector.Item, error) {
+	raw, err := c.Call("toggle", map[string]int{"index": index})
+	if err != nil {
+		return nil, err
+	}
+	var items []selector.Item
+	err = json.Unmarshal(raw, &items)
+	return items, err
+}
+
+// SetChecked sets the checked state of the item at index.
+func (c *Client) SetChecked(index int, checked bool) ([]selector.Item, error) {
+	raw, err := c.Call("setChecked", map[string]interface{}{"index": index, "checked": checked})
+	if err != nil {
+		return nil, err
+	}
+	var items []selector.Item
+	err = json.Unmarshal(raw, &items)
+	return items, err
+}
+
+// GetSelection returns the names of the currently checked items.
+func (c *Client) GetSelection() ([]string, error) {
+	raw, err := c.Call("getSelection", nil)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	err = json.Unmarshal(raw, &names)
+	return names, err
+}
+
+// Save persists the current selection and returns how many items were
+// written.
+func (c *Client) Save() (int, error) {
+	raw, err := c.Call("save", nil)
+	if err != nil {
+		return 0, err
+	}
+	var result struct {
+		Count int `json:"count"`
+	}
+	err = json.Unmarshal(raw, &result)
+	return result.Count, err
+}
@@ -0,0 +1,47 @@
+// Package rpc implements an LSP-style JSON-RPC 2.0 control mode for
+// selector.Model: newline-delimited requests and responses over stdio, plus
+// server-initiated notifications, so editors, scripts, or plugin hosts can
+// drive a selection without a TTY.
+package rpc
+
+import "encoding/json"
+
+// Request is a single JSON-RPC 2.0 call. A nil ID marks a notification.
+type Request struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+}
+
+// Response answers a Request with the same ID, echoed verbatim.
+type Response struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *Error           `json:"error,omitempty"`
+}
+
+// Notification is a server-initiated message that carries no ID and
+// expects no reply, such as selectionChanged.
+type Notification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// Error is a JSON-RPC 2.0 error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Standard JSON-RPC 2.0 error codes used by Server.
+const (
+	ErrParse          = -32700
+	ErrInvalidParams  = -32602
+	ErrMethodNotFound = -32601
+	ErrInternal       = -32603
+)
@@ -0,0 +1,137 @@
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/gq97a6/md_selector/pkg/selector"
+)
+
+// Server speaks newline-delimited JSON-RPC 2.0 over stdio, exposing a
+// selector.Model's state to external programs without a real terminal.
+// Requests are handled in the order they are read, and each response
+// carries the same ID as the request that produced it.
+type Server struct {
+	model *selector.Model
+	store selector.Store
+
+	outMu sync.Mutex
+	out   *json.Encoder
+}
+
+// NewServer returns a Server driving model, persisting via store, and
+// writing responses and notifications to w.
+func NewServer(model *selector.Model, store selector.Store, w io.Writer) *Server {
+	return &Server{model: model, store: store, out: json.NewEncoder(w)}
+}
+
+// Serve reads newline-delimited JSON-RPC requests from r until EOF or a
+// read error, replying to each over the writer given to NewServer.
+func (s *Server) Serve(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var req Request
+		if err := json.Unmarshal(line, &req); err != nil {
+			s.reply(nil, nil, &Error{Code: ErrParse, Message: "parse error: " + err.Error()})
+			continue
+		}
+		s.handle(req)
+	}
+	return scanner.Err()
+}
+
+func (s *Server) handle(req Request) {
+	result, rpcErr := s.dispatch(req)
+	if req.ID == nil {
+		return
+	}
+	s.reply(req.ID, result, rpcErr)
+}
+
+func (s *Server) dispatch(req Request) (interface{}, *Error) {
+	switch req.Method {
+	case "list":
+		return s.model.Items(), nil
+
+	case "toggle":
+		var p struct {
+			Index int `json:"index"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, &Error{Code: ErrInvalidParams, Message: err.Error()}
+		}
+		items := s.model.Items()
+		if p.Index < 0 || p.Index >= len(items) {
+			return nil, &Error{Code: ErrInvalidParams, Message: "index out of range"}
+		}
+		s.model.SetChecked(p.Index, !items[p.Index].Checked)
+		s.notifySelectionChanged()
+		return s.model.Items(), nil
+
+	case "setChecked":
+		var p struct {
+			Index   int  `json:"index"`
+			Checked bool `json:"checked"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, &Error{Code: ErrInvalidParams, Message: err.Error()}
+		}
+		if p.Index < 0 || p.Index >= len(s.model.Items()) {
+			return nil, &Error{Code: ErrInvalidParams, Message: "index out of range"}
+		}
+		s.model.SetChecked(p.Index, p.Checked)
+		s.notifySelectionChanged()
+		return s.model.Items(), nil
+
+	case "getSelection":
+		var names []string
+		for _, it := range s.model.Items() {
+			if it.Checked {
+				names = append(names, it.Name)
+			}
+		}
+		return names, nil
+
+	case "save":
+		count, err := s.store.Save(s.model.Items())
+		if err != nil {
+			return nil, &Error{Code: ErrInternal, Message: err.Error()}
+		}
+		return map[string]int{"count": count}, nil
+
+	default:
+		return nil, &Error{Code: ErrMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+func (s *Server) notifySelectionChanged() {
+	s.emit(Notification{JSONRPC: "2.0", Method: "selectionChanged", Params: s.model.Items()})
+}
+
+func (s *Server) reply(id *json.RawMessage, result interface{}, rpcErr *Error) {
+	resp := Response{JSONRPC: "2.0", ID: id, Error: rpcErr}
+	if rpcErr == nil && result != nil {
+		raw, err := json.Marshal(result)
+		if err != nil {
+			resp.Error = &Error{Code: ErrInternal, Message: err.Error()}
+		} else {
+			resp.Result = raw
+		}
+	}
+	s.emit(resp)
+}
+
+func (s *Server) emit(v interface{}) {
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	s.out.Encode(v)
+}
@@ -0,0 +1,78 @@
+package selector
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Store loads and saves which items are checked between runs.
+type Store interface {
+	// Load returns the set of previously checked item names.
+	Load() (map[string]bool, error)
+	// Save persists the checked items and returns how many were written.
+	Save(items []Item) (int, error)
+}
+
+// FileStore is a Store backed by a flat text file, one checked item name
+// per line. It is the Store cmd/mdselector uses by default.
+type FileStore struct {
+	Path string
+}
+
+// NewFileStore returns a FileStore backed by the file at path.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{Path: path}
+}
+
+var _ Store = (*FileStore)(nil)
+
+func (s *FileStore) Load() (map[string]bool, error) {
+	file, err := os.Open(s.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]bool{}, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	checked := map[string]bool{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" {
+			continue
+		}
+		checked[entry] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return checked, nil
+}
+
+func (s *FileStore) Save(items []Item) (int, error) {
+	var b strings.Builder
+	count := 0
+	for _, it := range items {
+		if !it.Checked {
+			continue
+		}
+		b.WriteString(it.Name)
+		b.WriteByte('\n')
+		count++
+	}
+
+	if dir := filepath.Dir(s.Path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return 0, err
+		}
+	}
+	if err := os.WriteFile(s.Path, []byte(b.String()), 0o644); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
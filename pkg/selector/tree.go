@@ -0,0 +1,205 @@
+package selector
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CheckState is the tri-state checkbox value shown for directory nodes,
+// derived from the checked state of their descendant files.
+type CheckState int
+
+const (
+	Unchecked CheckState = iota
+	Partial
+	Checked
+)
+
+// Node is one entry in the directory tree produced by Walk: either a
+// directory (IsDir, with Children) or a leaf *.md file (Item is set).
+type Node struct {
+	Name     string
+	Path     string
+	IsDir    bool
+	Expanded bool
+	Item     *Item
+	Children []*Node
+}
+
+// Walk recursively scans root on fsys and returns its tree, stopping
+// descent at maxDepth directories below root (0 means unlimited).
+// Directories with no markdown descendants are pruned, and Walk returns a
+// nil root if none were found at all.
+func Walk(fsys FS, root string, maxDepth int) (*Node, error) {
+	return walk(fsys, root, filepath.Base(root), 0, maxDepth)
+}
+
+func walk(fsys FS, path, name string, depth, maxDepth int) (*Node, error) {
+	entries, err := fsys.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &Node{Name: name, Path: path, IsDir: true, Expanded: true}
+	for _, entry := range entries {
+		childPath := filepath.Join(path, entry.Name())
+
+		if entry.IsDir() {
+			if maxDepth > 0 && depth+1 >= maxDepth {
+				continue
+			}
+			child, err := walk(fsys, childPath, entry.Name(), depth+1, maxDepth)
+			if err != nil {
+				return nil, err
+			}
+			if child != nil {
+				node.Children = append(node.Children, child)
+			}
+			continue
+		}
+
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext != ".md" {
+			continue
+		}
+		stem := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if stem == "" {
+			continue
+		}
+		node.Children = append(node.Children, &Node{
+			Name: entry.Name(),
+			Path: childPath,
+			Item: &Item{Name: stem, Path: childPath},
+		})
+	}
+
+	if len(node.Children) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(node.Children, func(i, j int) bool {
+		a, b := node.Children[i], node.Children[j]
+		if a.IsDir != b.IsDir {
+			return a.IsDir
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+
+	return node, nil
+}
+
+// State returns the tri-state checkbox value for node: Checked if every
+// descendant leaf is checked, Unchecked if none are, Partial otherwise.
+func (n *Node) State() CheckState {
+	if !n.IsDir {
+		if n.Item.Checked {
+			return Checked
+		}
+		return Unchecked
+	}
+
+	any, all := false, true
+	for _, c := range n.Children {
+		switch c.State() {
+		case Checked:
+			any = true
+		case Partial:
+			any, all = true, false
+		default:
+			all = false
+		}
+	}
+	switch {
+	case len(n.Children) == 0, !any:
+		return Unchecked
+	case any && all:
+		return Checked
+	default:
+		return Partial
+	}
+}
+
+// SetChecked recursively sets the checked state of every leaf under n.
+func (n *Node) SetChecked(checked bool) {
+	if !n.IsDir {
+		n.Item.SetChecked(checked)
+		return
+	}
+	for _, c := range n.Children {
+		c.SetChecked(checked)
+	}
+}
+
+// FlatNode pairs a Node with its depth in the tree, as produced by Flatten.
+type FlatNode struct {
+	Node  *Node
+	Depth int
+}
+
+// Flatten returns the nodes currently visible given their Expanded state,
+// in depth-first display order. The root itself is not included.
+func Flatten(root *Node) []FlatNode {
+	var out []FlatNode
+	var visit func(n *Node, depth int)
+	visit = func(n *Node, depth int) {
+		out = append(out, FlatNode{Node: n, Depth: depth})
+		if n.IsDir && !n.Expanded {
+			return
+		}
+		for _, c := range n.Children {
+			visit(c, depth+1)
+		}
+	}
+	for _, c := range root.Children {
+		visit(c, 0)
+	}
+	return out
+}
+
+// Leaves returns the Items of every *.md file under root, in tree order.
+func Leaves(root *Node) []Item {
+	var items []Item
+	var visit func(n *Node)
+	visit = func(n *Node) {
+		if !n.IsDir {
+			items = append(items, *n.Item)
+			return
+		}
+		for _, c := range n.Children {
+			visit(c)
+		}
+	}
+	visit(root)
+	return items
+}
+
+// ApplyCheckedTree marks the leaves under root found in checked, returning
+// an error if a checked entry does not correspond to a known leaf.
+func ApplyCheckedTree(root *Node, checked map[string]bool) error {
+	remaining := make(map[string]bool, len(checked))
+	for name := range checked {
+		remaining[name] = true
+	}
+
+	var visit func(n *Node)
+	visit = func(n *Node) {
+		if !n.IsDir {
+			if checked[n.Item.Name] {
+				n.Item.SetChecked(true)
+				delete(remaining, n.Item.Name)
+			}
+			return
+		}
+		for _, c := range n.Children {
+			visit(c)
+		}
+	}
+	visit(root)
+
+	for name := range remaining {
+		return fmt.Errorf("output.txt entry %q not found among markdown files", name)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package selector
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"testing"
+	"testing/fstest"
+)
+
+// memFS adapts a fstest.MapFS (an in-memory tree) to the FS interface, so
+// Walk can be exercised without touching disk.
+type memFS struct {
+	fsys fstest.MapFS
+}
+
+func (m memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if name == "." || name == "" {
+		return m.fsys.ReadDir(".")
+	}
+	return m.fsys.ReadDir(relPath(name))
+}
+
+func (m memFS) ReadFile(name string) ([]byte, error) {
+	return m.fsys.ReadFile(relPath(name))
+}
+
+func (m memFS) Open(name string) (io.ReadCloser, error) {
+	return m.fsys.Open(relPath(name))
+}
+
+// relPath strips the fixture's fake absolute prefix so lookups work
+// against an fs.FS, which requires slash-separated relative paths.
+func relPath(name string) string {
+	p := path.Clean(name)
+	for len(p) > 0 && p[0] == '/' {
+		p = p[1:]
+	}
+	if p == "" {
+		p = "."
+	}
+	return p
+}
+
+func newFixture() FS {
+	return memFS{fsys: fstest.MapFS{
+		"docs/intro.md":         {Data: []byte("# Intro\n")},
+		"docs/guide.md":         {Data: []byte("# Guide\n")},
+		"docs/nested/detail.md": {Data: []byte("# Detail\n")},
+		"README.md":             {Data: []byte("# README\n")},
+		"notes.txt":             {Data: []byte("not markdown")},
+	}}
+}
+
+func TestWalkBuildsTree(t *testing.T) {
+	root, err := Walk(newFixture(), ".", 0)
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if root == nil {
+		t.Fatal("expected a non-nil root")
+	}
+
+	leaves := Leaves(root)
+	if len(leaves) != 4 {
+		t.Fatalf("expected 4 markdown leaves, got %d: %+v", len(leaves), leaves)
+	}
+}
+
+func TestWalkMaxDepth(t *testing.T) {
+	root, err := Walk(newFixture(), ".", 2)
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	for _, it := range Leaves(root) {
+		if it.Name == "detail" {
+			t.Fatalf("expected docs/nested to be pruned at max-depth 2")
+		}
+	}
+}
+
+func TestNodeStateTriState(t *testing.T) {
+	root, err := Walk(newFixture(), ".", 0)
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if root.State() != Unchecked {
+		t.Fatalf("expected Unchecked before any check, got %v", root.State())
+	}
+
+	root.SetChecked(true)
+	if root.State() != Checked {
+		t.Fatalf("expected Checked after SetChecked(true), got %v", root.State())
+	}
+
+	leaves := Leaves(root)
+	leaves[0].Checked = false // direct mutation on the copy, not the tree
+
+	var docsNode *Node
+	for _, c := range root.Children {
+		if c.Name == "docs" {
+			docsNode = c
+		}
+	}
+	if docsNode == nil {
+		t.Fatal("expected a docs directory node")
+	}
+	var guideNode *Node
+	for _, c := range docsNode.Children {
+		if c.Name == "guide.md" {
+			guideNode = c
+		}
+	}
+	if guideNode == nil {
+		t.Fatal("expected a guide.md leaf node")
+	}
+	guideNode.Item.Checked = false
+	if docsNode.State() != Partial {
+		t.Fatalf("expected Partial once one leaf is unchecked, got %v", docsNode.State())
+	}
+}
@@ -0,0 +1,185 @@
+package selector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Writer encodes a finished selection to an output file. dir is the
+// directory the items were scanned from, for formats that need to
+// re-resolve an item's source file. Write returns how many items it wrote.
+type Writer interface {
+	Write(path, dir string, items []Item) (int, error)
+}
+
+// WriterFor returns the built-in Writer for format (one of "plain", "json",
+// "yaml", "markdown", "recfile"; "" is an alias for "plain").
+func WriterFor(format string) (Writer, error) {
+	switch strings.ToLower(format) {
+	case "", "plain":
+		return PlainWriter{}, nil
+	case "json":
+		return JSONWriter{}, nil
+	case "yaml":
+		return YAMLWriter{}, nil
+	case "markdown":
+		return MarkdownWriter{}, nil
+	case "recfile":
+		return RecfileWriter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+func checkedItems(items []Item) []Item {
+	var out []Item
+	for _, it := range items {
+		if it.Checked {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// PlainWriter writes one checked item name per line, matching the
+// original output.txt format.
+type PlainWriter struct{}
+
+func (PlainWriter) Write(path, dir string, items []Item) (int, error) {
+	var b strings.Builder
+	checked := checkedItems(items)
+	for _, it := range checked {
+		b.WriteString(it.Name)
+		b.WriteByte('\n')
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return 0, err
+	}
+	return len(checked), nil
+}
+
+type itemRecord struct {
+	Name      string `json:"name" yaml:"name"`
+	Path      string `json:"path,omitempty" yaml:"path,omitempty"`
+	CheckedAt string `json:"checked_at,omitempty" yaml:"checked_at,omitempty"`
+}
+
+func toRecords(items []Item) []itemRecord {
+	records := make([]itemRecord, 0, len(items))
+	for _, it := range items {
+		rec := itemRecord{Name: it.Name, Path: it.Path}
+		if !it.CheckedAt.IsZero() {
+			rec.CheckedAt = it.CheckedAt.UTC().Format(time.RFC3339)
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+// JSONWriter writes a JSON array of {name, path, checked_at}.
+type JSONWriter struct{}
+
+func (JSONWriter) Write(path, dir string, items []Item) (int, error) {
+	records := toRecords(checkedItems(items))
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
+
+// YAMLWriter writes the same records as JSONWriter, in YAML.
+type YAMLWriter struct{}
+
+func (YAMLWriter) Write(path, dir string, items []Item) (int, error) {
+	records := toRecords(checkedItems(items))
+	data, err := yaml.Marshal(records)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
+
+// MarkdownWriter concatenates each checked item's source file into one
+// Markdown document, separated by "---" rules, with a table of contents
+// of anchor links at the top.
+type MarkdownWriter struct{}
+
+func (MarkdownWriter) Write(path, dir string, items []Item) (int, error) {
+	checked := checkedItems(items)
+
+	var b strings.Builder
+	b.WriteString("# Table of contents\n\n")
+	for _, it := range checked {
+		fmt.Fprintf(&b, "- [%s](#%s)\n", it.Name, anchor(it.Name))
+	}
+	b.WriteString("\n")
+
+	for _, it := range checked {
+		source := it.Path
+		if source == "" {
+			source = filepath.Join(dir, it.Name+".md")
+		}
+		content, err := os.ReadFile(source)
+		if err != nil {
+			return 0, err
+		}
+		fmt.Fprintf(&b, "<a id=\"%s\"></a>\n## %s\n\n", anchor(it.Name), it.Name)
+		b.Write(content)
+		b.WriteString("\n\n---\n\n")
+	}
+
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return 0, err
+	}
+	return len(checked), nil
+}
+
+func anchor(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+}
+
+// RecfileWriter writes recutils-style records: one "Key: value" field per
+// line, records separated by a blank line.
+type RecfileWriter struct{}
+
+func (RecfileWriter) Write(path, dir string, items []Item) (int, error) {
+	checked := checkedItems(items)
+
+	var b strings.Builder
+	for _, it := range checked {
+		fmt.Fprintf(&b, "Name: %s\n", it.Name)
+		if it.Path != "" {
+			fmt.Fprintf(&b, "Path: %s\n", it.Path)
+		}
+		if !it.CheckedAt.IsZero() {
+			fmt.Fprintf(&b, "CheckedAt: %s\n", it.CheckedAt.UTC().Format(time.RFC3339))
+		}
+		b.WriteByte('\n')
+	}
+
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return 0, err
+	}
+	return len(checked), nil
+}
+
+var (
+	_ Writer = PlainWriter{}
+	_ Writer = JSONWriter{}
+	_ Writer = YAMLWriter{}
+	_ Writer = MarkdownWriter{}
+	_ Writer = RecfileWriter{}
+)
@@ -0,0 +1,122 @@
+package selector
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMarkdownWriterTOCAndAnchors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "intro.md"), []byte("intro body\n"), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Getting Started.md"), []byte("getting started body\n"), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	items := []Item{
+		{Name: "intro", Path: filepath.Join(dir, "intro.md"), Checked: true},
+		{Name: "Getting Started", Path: filepath.Join(dir, "Getting Started.md"), Checked: true},
+		{Name: "skipped", Path: filepath.Join(dir, "skipped.md")},
+	}
+
+	out := filepath.Join(dir, "out.md")
+	count, err := MarkdownWriter{}.Write(out, dir, items)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 checked items, got %d", count)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	doc := string(data)
+
+	if !strings.Contains(doc, "- [intro](#intro)") {
+		t.Fatalf("expected a TOC entry for intro, got:\n%s", doc)
+	}
+	if !strings.Contains(doc, "- [Getting Started](#getting-started)") {
+		t.Fatalf("expected a TOC entry for Getting Started, got:\n%s", doc)
+	}
+	if !strings.Contains(doc, "<a id=\"getting-started\"></a>") {
+		t.Fatalf("expected an anchor for Getting Started, got:\n%s", doc)
+	}
+	if !strings.Contains(doc, "intro body") || !strings.Contains(doc, "getting started body") {
+		t.Fatalf("expected both source bodies merged in, got:\n%s", doc)
+	}
+	if strings.Contains(doc, "skipped") {
+		t.Fatalf("expected unchecked items to be omitted, got:\n%s", doc)
+	}
+	if !strings.Contains(doc, "\n---\n") {
+		t.Fatalf("expected sections separated by a --- rule, got:\n%s", doc)
+	}
+}
+
+func TestJSONWriterRecordShape(t *testing.T) {
+	dir := t.TempDir()
+	checkedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	items := []Item{
+		{Name: "a", Path: "/docs/a.md", Checked: true, CheckedAt: checkedAt},
+		{Name: "b", Checked: false},
+	}
+
+	out := filepath.Join(dir, "out.json")
+	count, err := JSONWriter{}.Write(out, dir, items)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 checked item, got %d", count)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	var records []itemRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	if records[0].Name != "a" || records[0].Path != "/docs/a.md" {
+		t.Fatalf("unexpected record: %+v", records[0])
+	}
+	if records[0].CheckedAt != checkedAt.Format(time.RFC3339) {
+		t.Fatalf("expected RFC3339 checked_at %q, got %q", checkedAt.Format(time.RFC3339), records[0].CheckedAt)
+	}
+}
+
+func TestYAMLWriterRecordShape(t *testing.T) {
+	dir := t.TempDir()
+	items := []Item{{Name: "a", Path: "/docs/a.md", Checked: true}}
+
+	out := filepath.Join(dir, "out.yaml")
+	if _, err := (YAMLWriter{}).Write(out, dir, items); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	var records []itemRecord
+	if err := yaml.Unmarshal(data, &records); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(records) != 1 || records[0].Name != "a" || records[0].Path != "/docs/a.md" {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+}